@@ -94,19 +94,105 @@ export async function createFolder(name: string, path: string = "/") {
     }
 }
 
-export async function deleteItem(id: string, type: "file" | "folder") {
+export async function deleteItem(id: string) {
     const supabase = await createClient();
     const { data: { user }, error: authError } = await supabase.auth.getUser();
     if (authError || !user) return { error: "Unauthorized" };
 
     try {
+        const { data: item, error: fetchError } = await supabase
+            .from('files')
+            .select('name, type, path, repo_name, blob_path, size_bytes')
+            .eq('id', id)
+            .single();
+
+        if (fetchError) throw fetchError;
+
+        const items = [{ id, ...item }];
+        if (item.type === 'folder') {
+            // Children live at "<parent>/<name>", grandchildren below that
+            const folderPath = `${item.path === '/' ? '' : item.path}/${item.name}`;
+
+            const { data: direct, error: directError } = await supabase
+                .from('files')
+                .select('id, name, type, path, repo_name, blob_path, size_bytes')
+                .eq('path', folderPath);
+            if (directError) throw directError;
+
+            // `like` is only a prefilter: PostgREST reads `*` as a wildcard
+            // and folder names may contain it, so re-check the prefix here
+            // before anything is deleted.
+            const { data: nested, error: nestedError } = await supabase
+                .from('files')
+                .select('id, name, type, path, repo_name, blob_path, size_bytes')
+                .like('path', `${folderPath}/%`);
+            if (nestedError) throw nestedError;
+
+            items.push(
+                ...direct,
+                ...nested.filter((f: any) => f.path.startsWith(`${folderPath}/`))
+            );
+        }
+
+        const blobs = items.filter((f) => f.type === 'file' && f.repo_name && f.blob_path);
+
+        const byRepo = new Map<string, typeof blobs>();
+        for (const f of blobs) {
+            byRepo.set(f.repo_name, [...(byRepo.get(f.repo_name) || []), f]);
+        }
+
+        // Remove the blobs first so a failed GitHub delete keeps the rows
+        // around instead of leaking the blobs in the storage repo. Each repo
+        // gets a single commit, so a repo's blobs go away all or nothing.
+        if (blobs.length > 0) {
+            const { data: { session } } = await supabase.auth.getSession();
+            if (!session?.provider_token) return { error: "Unauthorized" };
+
+            const github = new GitHubService(session.provider_token);
+            for (const [repoName, repoBlobs] of byRepo) {
+                await github.deleteFiles(
+                    repoName,
+                    repoBlobs.map((f) => f.blob_path),
+                    `Delete ${item.name}`
+                );
+            }
+        }
+
         const { error } = await supabase
             .from('files')
             .delete()
-            .eq('id', id);
+            .in('id', items.map((f) => f.id));
 
         if (error) throw error;
 
+        // storage_repos.size_bytes is maintained here (uploadFile adds to it)
+        // and drives repo rotation, so give back what the blobs used.
+        // user_storage_usage is kept up by the trigger on `files`. The rows
+        // are already gone, so a failed size update must not fail the delete.
+        for (const [repoName, repoBlobs] of byRepo) {
+            const bytes = repoBlobs.reduce((sum, f) => sum + (f.size_bytes || 0), 0);
+            const { data: repo, error: repoError } = await supabase
+                .from('storage_repos')
+                .select('id, size_bytes')
+                .eq('user_id', user.id)
+                .eq('repo_name', repoName)
+                .single();
+
+            if (repoError) {
+                console.error(`Failed to load storage repo ${repoName}:`, repoError);
+                continue;
+            }
+
+            const { error: updateError } = await supabase
+                .from('storage_repos')
+                .update({ size_bytes: Math.max(0, repo.size_bytes - bytes) })
+                .eq('id', repo.id);
+
+            if (updateError) {
+                console.error(`Failed to update size of ${repoName}:`, updateError);
+            }
+        }
+
         return { success: true };
     } catch (error: any) {
         return { error: error.message };
@@ -126,7 +126,7 @@ export default function DashboardPage() {
     
     const { id, type } = itemToDelete
     setIsDeleting(id);
-    const result = await deleteItem(id, type);
+    const result = await deleteItem(id);
     setIsDeleting(null);
     setDeleteConfirmOpen(false)
     setItemToDelete(null)
@@ -261,4 +261,117 @@ export class GitHubService {
       sha,
     });
   }
+
+  async deleteFile(repo: string, path: string, message: string) {
+    await this.deleteFiles(repo, [path], message);
+  }
+
+  // Removes several paths in a single commit through the Git Data API, so a
+  // folder delete costs one commit per repo instead of one per file. Paths
+  // that are already gone are skipped; only tree metadata is read, never
+  // the blob contents.
+  async deleteFiles(repo: string, paths: string[], message: string) {
+    const username = await this.getUser();
+    if (paths.length === 0) return;
+
+    const { data: repoData } = await this.octokit.rest.repos.get({
+      owner: username,
+      repo,
+    });
+    const branch = repoData.default_branch;
+
+    // Retry when an upload moves the branch between reading and updating it
+    for (let attempt = 0; attempt < 3; attempt++) {
+      const { data: ref } = await this.octokit.rest.git.getRef({
+        owner: username,
+        repo,
+        ref: `heads/${branch}`,
+      });
+      const { data: commit } = await this.octokit.rest.git.getCommit({
+        owner: username,
+        repo,
+        commit_sha: ref.object.sha,
+      });
+
+      const entries = await this.listTreeEntries(username, repo, commit.tree.sha, paths);
+      const removals = paths.filter((path) => entries.has(path)).map((path) => {
+        if (entries.get(path)!.type !== "blob") {
+          throw new Error(`Path is not a file: ${path}`);
+        }
+        return {
+          path,
+          mode: entries.get(path)!.mode as "100644" | "100755" | "120000",
+          type: "blob" as const,
+          sha: null,
+        };
+      });
+      if (removals.length === 0) return; // Already gone, nothing to reclaim
+
+      const { data: tree } = await this.octokit.rest.git.createTree({
+        owner: username,
+        repo,
+        base_tree: commit.tree.sha,
+        tree: removals,
+      });
+      const { data: newCommit } = await this.octokit.rest.git.createCommit({
+        owner: username,
+        repo,
+        message,
+        tree: tree.sha,
+        parents: [commit.sha],
+      });
+
+      try {
+        await this.octokit.rest.git.updateRef({
+          owner: username,
+          repo,
+          ref: `heads/${branch}`,
+          sha: newCommit.sha,
+        });
+        return;
+      } catch (e: any) {
+        // Not a fast-forward: the branch moved, rebuild on the new head
+        if (e.status !== 422 || attempt === 2) throw e;
+      }
+    }
+  }
+
+  // Resolves the tree entries for `paths` by listing only the directories
+  // they live in, keyed by full path.
+  private async listTreeEntries(owner: string, repo: string, rootSha: string, paths: string[]) {
+    const entries = new Map<string, { type: string; mode: string; sha: string }>();
+    const listed = new Set<string>();
+
+    const listDir = async (dir: string): Promise<boolean> => {
+      if (listed.has(dir)) return true;
+
+      let sha = rootSha;
+      if (dir) {
+        const slash = dir.lastIndexOf("/");
+        if (!(await listDir(slash === -1 ? "" : dir.substring(0, slash)))) return false;
+        const entry = entries.get(dir);
+        if (!entry || entry.type !== "tree") return false;
+        sha = entry.sha;
+      }
+
+      const { data } = await this.octokit.rest.git.getTree({ owner, repo, tree_sha: sha });
+      for (const item of data.tree) {
+        if (!item.path || !item.type || !item.mode || !item.sha) continue;
+        entries.set(dir ? `${dir}/${item.path}` : item.path, {
+          type: item.type,
+          mode: item.mode,
+          sha: item.sha,
+        });
+      }
+      listed.add(dir);
+      return true;
+    };
+
+    for (const path of paths) {
+      const slash = path.lastIndexOf("/");
+      await listDir(slash === -1 ? "" : path.substring(0, slash));
+    }
+
+    return entries;
+  }
 }